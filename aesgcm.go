@@ -0,0 +1,76 @@
+package bwt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+)
+
+// AES256GCM is the AES-256-GCM EncryptionAlgorithm.
+// Expects a 32-byte symmetric key for both Encrypt and Decrypt.
+var AES256GCM *algoAESGCM
+
+func init() {
+	AES256GCM = new(algoAESGCM)
+	RegisterEncryptionAlgorithm(AES256GCM)
+}
+
+type algoAESGCM struct{}
+
+func (*algoAESGCM) Name() string { return "AES256GCM" }
+
+func (a *algoAESGCM) aead(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt implements token encryption. Key must be a 32-byte []byte.
+func (a *algoAESGCM) Encrypt(prefix string, plaintext []byte, key Key) (nonce, ciphertext, tag []byte, err error) {
+	keyBytes, err := KeyAs[[]byte](key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	aead, err := a.aead(keyBytes)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	nonce = make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, nil, err
+	}
+
+	sealed := aead.Seal(nil, nonce, plaintext, []byte(prefix))
+	ciphertext, tag = splitTag(sealed, aead.Overhead())
+	return nonce, ciphertext, tag, nil
+}
+
+// Decrypt implements token decryption. Key must be a 32-byte []byte.
+func (a *algoAESGCM) Decrypt(prefix string, nonce, ciphertext, tag []byte, key PrivateKey) ([]byte, error) {
+	keyBytes, err := KeyAs[[]byte](key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := a.aead(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, ErrInvalidKey
+	}
+	if len(tag) != aead.Overhead() {
+		return nil, ErrTagInvalid
+	}
+
+	plaintext, err := aead.Open(nil, nonce, append(ciphertext, tag...), []byte(prefix))
+	if err != nil {
+		return nil, ErrWrongTag
+	}
+	return plaintext, nil
+}
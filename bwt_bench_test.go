@@ -0,0 +1,102 @@
+package bwt_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/karalef/bwt"
+)
+
+func benchClaims() bwt.ClaimsMap {
+	return bwt.ClaimsMap{
+		bwt.ClaimsKeySubject:  "1234567890",
+		"name":                "John Doe",
+		bwt.ClaimsKeyIssuedAt: time.Unix(1516239022, 0),
+	}
+}
+
+func BenchmarkAuthenticate(b *testing.B) {
+	secret := make([]byte, 32)
+	//nolint:errcheck
+	rand.Read(secret)
+	claims := benchClaims()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		token := bwt.New(bwt.HS256, claims)
+		if _, err := token.Authenticate(secret); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAuthenticateTo(b *testing.B) {
+	secret := make([]byte, 32)
+	//nolint:errcheck
+	rand.Read(secret)
+	claims := benchClaims()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		token := bwt.New(bwt.HS256, claims)
+		if err := token.AuthenticateTo(io.Discard, secret); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAuthenticateEdDSA(b *testing.B) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		b.Fatal(err)
+	}
+	claims := benchClaims()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		token := bwt.New(bwt.EdDSA, claims)
+		if _, err := token.Authenticate(priv); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	secret := make([]byte, 32)
+	//nolint:errcheck
+	rand.Read(secret)
+	signed, err := bwt.New(bwt.HS256, benchClaims()).Authenticate(secret)
+	if err != nil {
+		b.Fatal(err)
+	}
+	keyFunc := bwt.KeyfuncFrom(secret)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := bwt.Parse(signed, keyFunc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseReader(b *testing.B) {
+	secret := make([]byte, 32)
+	//nolint:errcheck
+	rand.Read(secret)
+	signed, err := bwt.New(bwt.HS256, benchClaims()).Authenticate(secret)
+	if err != nil {
+		b.Fatal(err)
+	}
+	keyFunc := bwt.KeyfuncFrom(secret)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := bwt.ParseReader(bytes.NewReader([]byte(signed)), keyFunc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Package bwthttp provides net/http integration for bwt.
+package bwthttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/karalef/bwt"
+)
+
+// ErrNoBearerToken is returned when a request carries no extractable bearer token.
+var ErrNoBearerToken = errors.New("bwthttp: no bearer token found in request")
+
+type contextKey struct{}
+
+// TokenFromContext returns the *bwt.Token injected by Middleware, if any.
+func TokenFromContext(ctx context.Context) (*bwt.Token, bool) {
+	token, ok := ctx.Value(contextKey{}).(*bwt.Token)
+	return token, ok
+}
+
+// Option configures Middleware.
+type Option func(*options)
+
+type options struct {
+	allowOAuthBearerSASL bool
+}
+
+// WithOAuthBearerSASL additionally extracts the bearer token from the SASL
+// OAUTHBEARER GS2 framing ("n,,\x01auth=Bearer <token>\x01\x01") used by
+// IRC/IMAP clients, alongside the standard Authorization header.
+func WithOAuthBearerSASL(enabled bool) Option {
+	return func(o *options) { o.allowOAuthBearerSASL = enabled }
+}
+
+// Middleware returns net/http middleware that extracts a bearer token from
+// the request's Authorization header, verifies it with parser and keyFunc,
+// and injects the parsed *bwt.Token into the request context for downstream
+// handlers to retrieve with TokenFromContext. A request carrying no usable
+// token, or one that fails verification, is rejected with 401 and a
+// WriteChallenge response.
+func Middleware(parser *bwt.Parser, keyFunc bwt.Keyfunc, opts ...Option) func(http.Handler) http.Handler {
+	o := &options{}
+	for _, apply := range opts {
+		apply(o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw, err := extractToken(r, o)
+			if err != nil {
+				WriteChallenge(w, err)
+				return
+			}
+
+			token, err := parser.Parse(raw, keyFunc)
+			if err != nil {
+				WriteChallenge(w, err)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), contextKey{}, token)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func extractToken(r *http.Request, o *options) (string, error) {
+	auth := r.Header.Get("Authorization")
+
+	if scheme, token, ok := strings.Cut(auth, " "); ok && strings.EqualFold(scheme, "Bearer") && token != "" {
+		return token, nil
+	}
+
+	if o.allowOAuthBearerSASL {
+		if token, ok := extractGS2Bearer(auth); ok {
+			return token, nil
+		}
+	}
+
+	return "", ErrNoBearerToken
+}
+
+// extractGS2Bearer parses a SASL OAUTHBEARER GS2 header for its bearer token.
+func extractGS2Bearer(s string) (string, bool) {
+	const marker = "auth=Bearer "
+	i := strings.Index(s, marker)
+	if i < 0 {
+		return "", false
+	}
+
+	rest := s[i+len(marker):]
+	end := strings.IndexByte(rest, '\x01')
+	if end < 0 {
+		end = len(rest)
+	}
+
+	token := rest[:end]
+	if token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// WriteChallenge writes an RFC 6750-shaped 401 response with a
+// "WWW-Authenticate: Bearer" header describing why the token was rejected.
+func WriteChallenge(w http.ResponseWriter, err error) {
+	code, desc := challengeFor(err)
+	w.Header().Set("WWW-Authenticate", `Bearer error="`+code+`", error_description="`+desc+`"`)
+	w.WriteHeader(http.StatusUnauthorized)
+}
+
+func challengeFor(err error) (code, desc string) {
+	switch {
+	case errors.Is(err, ErrNoBearerToken), errors.Is(err, bwt.ErrTokenMalformed):
+		return "invalid_request", "the token is malformed or missing"
+	case errors.Is(err, bwt.ErrTokenExpired):
+		return "invalid_token", "the token is expired"
+	case errors.Is(err, bwt.ErrTokenTagInvalid):
+		return "invalid_token", "the token signature is invalid"
+	case errors.Is(err, bwt.ErrTokenInvalidClaims):
+		return "invalid_token", "the token claims are invalid"
+	case errors.Is(err, bwt.ErrTokenUnverifiable):
+		return "invalid_token", "the token could not be verified"
+	default:
+		return "invalid_token", "the token is invalid"
+	}
+}
@@ -0,0 +1,91 @@
+package bwthttp_test
+
+import (
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/karalef/bwt"
+	"github.com/karalef/bwt/bwthttp"
+)
+
+func newHandler(t *testing.T, opts ...bwthttp.Option) (http.Handler, []byte) {
+	t.Helper()
+	secret := make([]byte, 32)
+	//nolint:errcheck
+	rand.Read(secret)
+
+	mw := bwthttp.Middleware(bwt.NewParser(), bwt.KeyfuncFrom(secret), opts...)
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := bwthttp.TokenFromContext(r.Context()); !ok {
+			t.Error("expected a *bwt.Token in the request context")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	return handler, secret
+}
+
+func TestMiddlewareBearerToken(t *testing.T) {
+	handler, secret := newHandler(t)
+
+	signed, err := bwt.New(bwt.HS256, bwt.ClaimsMap{}).Authenticate(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+signed)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestMiddlewareMissingToken(t *testing.T) {
+	handler, _ := newHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("WWW-Authenticate"); got == "" {
+		t.Fatal("expected a WWW-Authenticate challenge header")
+	}
+}
+
+func TestMiddlewareInvalidToken(t *testing.T) {
+	handler, _ := newHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer not-a-real-token")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
+
+func TestMiddlewareOAuthBearerSASL(t *testing.T) {
+	handler, secret := newHandler(t, bwthttp.WithOAuthBearerSASL(true))
+
+	signed, err := bwt.New(bwt.HS256, bwt.ClaimsMap{}).Authenticate(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "n,,\x01auth=Bearer "+signed+"\x01\x01")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+}
@@ -3,6 +3,7 @@ package bwt
 import (
 	"crypto"
 	"errors"
+	"fmt"
 	"strings"
 	"sync"
 
@@ -39,7 +40,10 @@ type PrivateKey interface {
 func KeyAs[T any](key any) (T, error) {
 	v, ok := key.(T)
 	if !ok {
-		return v, ErrInvalidKeyType
+		return v, &InvalidKeyTypeError{
+			Expected: fmt.Sprintf("%T", v),
+			Received: fmt.Sprintf("%T", key),
+		}
 	}
 	return v, nil
 }
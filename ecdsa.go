@@ -0,0 +1,83 @@
+package bwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+
+	_ "golang.org/x/crypto/sha3"
+)
+
+// SHA3 based ECDSA
+var (
+	ES256 *algoECDSA
+	ES384 *algoECDSA
+	ES512 *algoECDSA
+)
+
+func init() {
+	ES256 = &algoECDSA{"ES256", elliptic.P256(), crypto.SHA3_256}
+	RegisterAlgorithm(ES256)
+
+	ES384 = &algoECDSA{"ES384", elliptic.P384(), crypto.SHA3_384}
+	RegisterAlgorithm(ES384)
+
+	ES512 = &algoECDSA{"ES512", elliptic.P521(), crypto.SHA3_512}
+	RegisterAlgorithm(ES512)
+}
+
+type algoECDSA struct {
+	alg   string
+	curve elliptic.Curve
+	hash  crypto.Hash
+}
+
+func (a *algoECDSA) Name() string { return a.alg }
+
+func (a *algoECDSA) digest(prefix string, body []byte) ([]byte, error) {
+	if !a.hash.Available() {
+		return nil, ErrHashUnavailable
+	}
+	h := a.hash.New()
+	h.Write([]byte(prefix))
+	h.Write(body)
+	return h.Sum(nil), nil
+}
+
+// Verify implements token verification.
+// Key must be an *ecdsa.PublicKey on the algorithm's curve.
+func (a *algoECDSA) Verify(prefix string, body []byte, key Key, tag []byte) error {
+	ecdsaKey, err := KeyAs[*ecdsa.PublicKey](key)
+	if err != nil {
+		return err
+	}
+	if ecdsaKey.Curve != a.curve {
+		return ErrInvalidKey
+	}
+	digest, err := a.digest(prefix, body)
+	if err != nil {
+		return err
+	}
+	if !ecdsa.VerifyASN1(ecdsaKey, digest, tag) {
+		return ErrWrongTag
+	}
+	return nil
+}
+
+// Auth implements token authentication.
+// Key must be an *ecdsa.PrivateKey on the algorithm's curve.
+func (a *algoECDSA) Auth(prefix string, body []byte, key PrivateKey) ([]byte, error) {
+	ecdsaKey, err := KeyAs[*ecdsa.PrivateKey](key)
+	if err != nil {
+		return nil, err
+	}
+	if ecdsaKey.Curve != a.curve {
+		return nil, ErrInvalidKey
+	}
+	digest, err := a.digest(prefix, body)
+	if err != nil {
+		return nil, err
+	}
+	return ecdsa.SignASN1(rand.Reader, ecdsaKey, digest)
+}
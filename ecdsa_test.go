@@ -0,0 +1,40 @@
+package bwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/karalef/bwt"
+)
+
+func TestECDSA(t *testing.T) {
+	cases := []struct {
+		alg   bwt.Algorithm
+		curve elliptic.Curve
+	}{
+		{bwt.ES256, elliptic.P256()},
+		{bwt.ES384, elliptic.P384()},
+		{bwt.ES512, elliptic.P521()},
+	}
+
+	for _, c := range cases {
+		t.Run(c.alg.Name(), func(t *testing.T) {
+			key, err := ecdsa.GenerateKey(c.curve, rand.Reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token := bwt.New(c.alg, bwt.ClaimsMap{bwt.ClaimsKeySubject: "1234567890"})
+			signed, err := token.Authenticate(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := bwt.Parse(signed, bwt.KeyfuncFrom(&key.PublicKey)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
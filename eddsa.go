@@ -4,6 +4,7 @@ import (
 	"crypto"
 	"crypto/ed25519"
 	"crypto/rand"
+	"sync"
 )
 
 // EdDSA algorithm.
@@ -19,6 +20,24 @@ type algoEd25519 struct{}
 
 func (*algoEd25519) Name() string { return "EDDSA" }
 
+// signingBufPool holds the buffers used to assemble prefix||body before
+// signing or verification, avoiding an allocation per call.
+var signingBufPool = sync.Pool{
+	New: func() any { return new([]byte) },
+}
+
+// signingBuffer returns a pooled buffer containing prefix||body. The
+// returned put func must be called once the buffer is no longer needed.
+func signingBuffer(prefix string, body []byte) (buf []byte, put func()) {
+	bufp := signingBufPool.Get().(*[]byte)
+	buf = append((*bufp)[:0], prefix...)
+	buf = append(buf, body...)
+	return buf, func() {
+		*bufp = buf
+		signingBufPool.Put(bufp)
+	}
+}
+
 // Verify implements token verification.
 // Key must be an ed25519.PublicKey.
 func (a *algoEd25519) Verify(prefix string, body []byte, key Key, tag []byte) error {
@@ -33,7 +52,10 @@ func (a *algoEd25519) Verify(prefix string, body []byte, key Key, tag []byte) er
 		return ErrTagInvalid
 	}
 
-	if !ed25519.Verify(ed25519Key, append([]byte(prefix), body...), tag) {
+	buf, put := signingBuffer(prefix, body)
+	defer put()
+
+	if !ed25519.Verify(ed25519Key, buf, tag) {
 		return ErrWrongTag
 	}
 	return nil
@@ -49,5 +71,9 @@ func (a *algoEd25519) Auth(prefix string, body []byte, key PrivateKey) ([]byte,
 	if len(ed25519Key) != ed25519.PrivateKeySize {
 		return nil, ErrInvalidKey
 	}
-	return ed25519Key.Sign(rand.Reader, append([]byte(prefix), body...), crypto.Hash(0))
+
+	buf, put := signingBuffer(prefix, body)
+	defer put()
+
+	return ed25519Key.Sign(rand.Reader, buf, crypto.Hash(0))
 }
@@ -0,0 +1,195 @@
+package bwt
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// EncryptedType is "BWE"
+const EncryptedType = "BWE"
+
+// EncryptionAlgorithm represents an AEAD algorithm used by EncryptedToken to
+// keep claims confidential.
+type EncryptionAlgorithm interface {
+	Name() string
+	// Encrypt encrypts plaintext associated with prefix and returns the
+	// nonce, ciphertext and authentication tag to be placed on the wire.
+	Encrypt(prefix string, plaintext []byte, key Key) (nonce, ciphertext, tag []byte, err error)
+	// Decrypt authenticates and decrypts a nonce/ciphertext/tag produced by Encrypt.
+	Decrypt(prefix string, nonce, ciphertext, tag []byte, key PrivateKey) (plaintext []byte, err error)
+}
+
+var registeredEncAlgs = make(map[string]EncryptionAlgorithm)
+var registeredEncAlgsMut sync.RWMutex
+
+// RegisterEncryptionAlgorithm registers an EncryptionAlgorithm for use by EncryptedToken and ParseEncrypted.
+func RegisterEncryptionAlgorithm(alg EncryptionAlgorithm) {
+	registeredEncAlgsMut.Lock()
+	registeredEncAlgs[strings.ToUpper(alg.Name())] = alg
+	registeredEncAlgsMut.Unlock()
+}
+
+// GetEncryptionAlgorithm retrieves an encryption algorithm from an "alg" string.
+func GetEncryptionAlgorithm(alg string) EncryptionAlgorithm {
+	registeredEncAlgsMut.RLock()
+	defer registeredEncAlgsMut.RUnlock()
+	return registeredEncAlgs[strings.ToUpper(alg)]
+}
+
+// NewEncrypted creates a new EncryptedToken.
+func NewEncrypted(alg EncryptionAlgorithm, claims Claims) *EncryptedToken {
+	return &EncryptedToken{
+		Algorithm: alg,
+		Claims:    claims,
+	}
+}
+
+// EncryptedToken represents a BWE token: a BWT-shaped token whose claims are
+// encrypted rather than plaintext base64url, for use when claims must stay
+// opaque to intermediaries.
+type EncryptedToken struct {
+	Algorithm EncryptionAlgorithm
+	Claims    Claims
+	Nonce     []byte
+	Tag       []byte
+}
+
+// Prefix returns the token prefix.
+func (t EncryptedToken) Prefix() string {
+	return EncryptedType + "_" + t.Algorithm.Name()
+}
+
+// Authenticate encrypts the claims and returns the encoded token in the
+// form BWE_<alg>.<nonce>.<ciphertext>.<tag>.
+func (t *EncryptedToken) Authenticate(key Key) (string, error) {
+	plaintext, err := msgpack.Marshal(t.Claims)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := t.Prefix()
+	nonce, ciphertext, tag, err := t.Algorithm.Encrypt(prefix, plaintext, key)
+	if err != nil {
+		return "", err
+	}
+	t.Nonce = nonce
+	t.Tag = tag
+
+	return prefix + "." + Encode(nonce) + "." + Encode(ciphertext) + "." + Encode(tag), nil
+}
+
+// DecryptKeyfunc is a callback function to supply the key for decryption.
+// The function receives the parsed, but not yet decrypted, EncryptedToken.
+type DecryptKeyfunc func(*EncryptedToken) (PrivateKey, error)
+
+// DecryptKeyfuncFrom returns a DecryptKeyfunc that always returns the same key.
+func DecryptKeyfuncFrom(key PrivateKey) DecryptKeyfunc {
+	return func(t *EncryptedToken) (PrivateKey, error) {
+		return key, nil
+	}
+}
+
+// ParseEncrypted decrypts, validates and returns a parsed EncryptedToken.
+func (p *Parser) ParseEncrypted(tokenString string, keyFunc DecryptKeyfunc) (*EncryptedToken, error) {
+	return p.ParseEncryptedWithClaims(tokenString, new(ClaimsMap), keyFunc)
+}
+
+// ParseEncryptedWithClaims decrypts and validates like ParseEncrypted but using the given claims.
+// Claims must be pointer.
+func (p *Parser) ParseEncryptedWithClaims(tokenString string, claims Claims, keyFunc DecryptKeyfunc) (*EncryptedToken, error) {
+	parts := strings.Split(tokenString, ".")
+	if len(parts) != 4 {
+		return nil, ErrTokenMalformed
+	}
+
+	typ, alg, ok := strings.Cut(parts[0], "_")
+	if !ok || typ != EncryptedType || alg == "" {
+		return nil, ErrTokenMalformed
+	}
+
+	token := &EncryptedToken{Claims: claims}
+	if token.Algorithm = GetEncryptionAlgorithm(alg); token.Algorithm == nil {
+		return token, ErrTokenUnverifiable
+	}
+
+	nonce, err := Decode(parts[1])
+	if err != nil {
+		return token, errors.Join(ErrTokenMalformed, err)
+	}
+	token.Nonce = nonce
+
+	ciphertext, err := Decode(parts[2])
+	if err != nil {
+		return token, errors.Join(ErrTokenMalformed, err)
+	}
+
+	token.Tag, err = Decode(parts[3])
+	if err != nil {
+		return token, errors.Join(ErrTokenMalformed, err)
+	}
+
+	if keyFunc == nil {
+		return token, errors.Join(errors.New("no keyfunc was provided"), ErrTokenUnverifiable)
+	}
+	key, err := keyFunc(token)
+	if err != nil {
+		return token, errors.Join(ErrTokenUnverifiable, err)
+	}
+
+	plaintext, err := token.Algorithm.Decrypt(token.Prefix(), token.Nonce, ciphertext, token.Tag, key)
+	if err != nil {
+		return token, errors.Join(ErrTokenTagInvalid, err)
+	}
+
+	if err := msgpack.Unmarshal(plaintext, claims); err != nil {
+		return token, errors.Join(ErrTokenMalformed, err)
+	}
+	token.Claims = claims
+
+	if p.validator != nil {
+		if err := p.validator.Validate(claims, tokenString); err != nil {
+			return token, errors.Join(ErrTokenInvalidClaims, err)
+		}
+	}
+
+	return token, nil
+}
+
+// ParseEncrypted is a shortcut for NewParser().ParseEncrypted().
+func ParseEncrypted(tokenString string, keyFunc DecryptKeyfunc, v ...*Validator) (*EncryptedToken, error) {
+	return NewParser(v...).ParseEncrypted(tokenString, keyFunc)
+}
+
+// ParseEncryptedWithClaims is a shortcut for NewParser().ParseEncryptedWithClaims().
+func ParseEncryptedWithClaims(tokenString string, claims Claims, keyFunc DecryptKeyfunc, v ...*Validator) (*EncryptedToken, error) {
+	return NewParser(v...).ParseEncryptedWithClaims(tokenString, claims, keyFunc)
+}
+
+// AnyToken holds the result of parsing a token whose wire type (BWT or BWE)
+// was not known ahead of time. On success exactly one of Token or
+// EncryptedToken is set; on error either may be nil.
+type AnyToken struct {
+	Token          *Token
+	EncryptedToken *EncryptedToken
+}
+
+// ParseAny transparently detects whether tokenString is a BWT_ or BWE_ token,
+// parses it accordingly and returns the result wrapped in an AnyToken.
+func (p *Parser) ParseAny(tokenString string, keyFunc Keyfunc, decryptKeyFunc DecryptKeyfunc) (*AnyToken, error) {
+	prefix, _, _ := strings.Cut(tokenString, ".")
+	typ, _, _ := strings.Cut(prefix, "_")
+
+	switch typ {
+	case Type:
+		token, err := p.Parse(tokenString, keyFunc)
+		return &AnyToken{Token: token}, err
+	case EncryptedType:
+		token, err := p.ParseEncrypted(tokenString, decryptKeyFunc)
+		return &AnyToken{EncryptedToken: token}, err
+	default:
+		return nil, ErrTokenMalformed
+	}
+}
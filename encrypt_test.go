@@ -0,0 +1,98 @@
+package bwt_test
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"testing"
+
+	"github.com/karalef/bwt"
+)
+
+func TestEncryptedToken(t *testing.T) {
+	key := make([]byte, 32)
+	//nolint:errcheck
+	rand.Read(key)
+
+	algs := []bwt.EncryptionAlgorithm{bwt.AES256GCM, bwt.XChaCha20Poly1305}
+	for _, alg := range algs {
+		t.Run(alg.Name(), func(t *testing.T) {
+			token := bwt.NewEncrypted(alg, bwt.ClaimsMap{bwt.ClaimsKeySubject: "1234567890"})
+			signed, err := token.Authenticate(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			parsed, err := bwt.ParseEncrypted(signed, bwt.DecryptKeyfuncFrom(key))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.Claims.GetSubject() != "1234567890" {
+				t.Fatalf("got subject %q", parsed.Claims.GetSubject())
+			}
+		})
+	}
+}
+
+func TestEncryptedTokenX25519(t *testing.T) {
+	curve := ecdh.X25519()
+	priv, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := bwt.NewEncrypted(bwt.X25519HKDF, bwt.ClaimsMap{bwt.ClaimsKeySubject: "1234567890"})
+	signed, err := token.Authenticate(priv.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := bwt.ParseEncrypted(signed, bwt.DecryptKeyfuncFrom(priv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Claims.GetSubject() != "1234567890" {
+		t.Fatalf("got subject %q", parsed.Claims.GetSubject())
+	}
+}
+
+// TestParseAnyDispatch verifies that Parser.ParseAny transparently detects a
+// token's BWT_ vs BWE_ prefix and routes it to the matching pipeline, and
+// that the single-type Parse rejects a BWE_ token.
+func TestParseAnyDispatch(t *testing.T) {
+	secret := make([]byte, 32)
+	//nolint:errcheck
+	rand.Read(secret)
+
+	signedBWT, err := bwt.New(bwt.HS256, bwt.ClaimsMap{}).Authenticate(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedBWE, err := bwt.NewEncrypted(bwt.AES256GCM, bwt.ClaimsMap{}).Authenticate(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := bwt.NewParser()
+	keyFunc := bwt.KeyfuncFrom(secret)
+	decryptKeyFunc := bwt.DecryptKeyfuncFrom(secret)
+
+	any, err := p.ParseAny(signedBWT, keyFunc, decryptKeyFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if any.Token == nil || any.EncryptedToken != nil {
+		t.Fatal("expected ParseAny to route a BWT_ token to Token")
+	}
+
+	any, err = p.ParseAny(signedBWE, keyFunc, decryptKeyFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if any.EncryptedToken == nil || any.Token != nil {
+		t.Fatal("expected ParseAny to route a BWE_ token to EncryptedToken")
+	}
+
+	if _, err := p.Parse(signedBWE, keyFunc); err == nil {
+		t.Fatal("expected Parse to reject a BWE_ token")
+	}
+}
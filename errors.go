@@ -0,0 +1,65 @@
+package bwt
+
+import (
+	"fmt"
+	"time"
+)
+
+// InvalidKeyTypeError is returned when a key of an unexpected type is
+// supplied to an Algorithm or EncryptionAlgorithm. It satisfies
+// errors.Is(err, ErrInvalidKeyType).
+type InvalidKeyTypeError struct {
+	Expected string
+	Received string
+}
+
+func (e *InvalidKeyTypeError) Error() string {
+	return fmt.Sprintf("bwt: invalid key type: expected %s, got %s", e.Expected, e.Received)
+}
+
+func (e *InvalidKeyTypeError) Unwrap() error { return ErrInvalidKeyType }
+
+// TokenExpiredError is returned by WithVerifyExpiration when a token's
+// expiration time has passed. It satisfies errors.Is(err, ErrTokenExpired).
+type TokenExpiredError struct {
+	ExpiredBy time.Duration
+}
+
+func (e *TokenExpiredError) Error() string {
+	return fmt.Sprintf("bwt: token is expired by %s", e.ExpiredBy)
+}
+
+func (e *TokenExpiredError) Unwrap() error { return ErrTokenExpired }
+
+// RequiredClaimMissingError is returned when a claim required by a validator
+// option is absent. It satisfies errors.Is(err, ErrTokenRequiredClaimMissing).
+type RequiredClaimMissingError struct {
+	Claim string
+}
+
+func (e *RequiredClaimMissingError) Error() string {
+	return fmt.Sprintf("bwt: required claim %q is missing", e.Claim)
+}
+
+func (e *RequiredClaimMissingError) Unwrap() error { return ErrTokenRequiredClaimMissing }
+
+// ValidationError aggregates the errors produced by a single call to
+// Validator.Validate, letting callers inspect which claims failed
+// individually instead of pattern-matching a joined error string.
+type ValidationError struct {
+	errs []error
+}
+
+func (e *ValidationError) Error() string {
+	s := "bwt: token has invalid claims:"
+	for _, err := range e.errs {
+		s += " " + err.Error() + ";"
+	}
+	return s
+}
+
+// Unwrap allows errors.Is/errors.As to reach any of the aggregated errors.
+func (e *ValidationError) Unwrap() []error { return e.errs }
+
+// Errors returns the individual per-claim validation failures.
+func (e *ValidationError) Errors() []error { return e.errs }
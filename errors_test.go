@@ -0,0 +1,58 @@
+package bwt_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/karalef/bwt"
+)
+
+func TestStructuredErrors(t *testing.T) {
+	t.Run("InvalidKeyType", func(t *testing.T) {
+		err := &bwt.InvalidKeyTypeError{Expected: "ed25519.PublicKey", Received: "[]uint8"}
+		if !errors.Is(err, bwt.ErrInvalidKeyType) {
+			t.Fatal("expected errors.Is to match ErrInvalidKeyType")
+		}
+	})
+
+	t.Run("TokenExpired", func(t *testing.T) {
+		err := &bwt.TokenExpiredError{ExpiredBy: time.Minute}
+		if !errors.Is(err, bwt.ErrTokenExpired) {
+			t.Fatal("expected errors.Is to match ErrTokenExpired")
+		}
+	})
+
+	t.Run("RequiredClaimMissing", func(t *testing.T) {
+		err := &bwt.RequiredClaimMissingError{Claim: bwt.ClaimsKeySubject}
+		if !errors.Is(err, bwt.ErrTokenRequiredClaimMissing) {
+			t.Fatal("expected errors.Is to match ErrTokenRequiredClaimMissing")
+		}
+	})
+}
+
+// TestValidationErrorAggregation checks that Validator.Validate reports
+// every failing claim individually through ValidationError rather than
+// stopping at the first one, and that errors.Is/As can still reach them.
+func TestValidationErrorAggregation(t *testing.T) {
+	v := bwt.NewValidator(
+		bwt.WithVerifyExpiration(true),
+		bwt.WithVerifySubject(true, "alice"),
+	)
+
+	err := v.Validate(bwt.ClaimsMap{})
+	if err == nil {
+		t.Fatal("expected validation to fail")
+	}
+
+	var verr *bwt.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected a *bwt.ValidationError, got %T", err)
+	}
+	if len(verr.Errors()) != 2 {
+		t.Fatalf("expected 2 aggregated errors, got %d: %v", len(verr.Errors()), verr.Errors())
+	}
+	if !errors.Is(err, bwt.ErrTokenRequiredClaimMissing) {
+		t.Fatal("expected errors.Is to reach an aggregated error via Unwrap() []error")
+	}
+}
@@ -0,0 +1,68 @@
+package bwt_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/karalef/bwt"
+)
+
+// TestWithRemoteIntrospectionOrdering checks that introspection's claim
+// overrides are visible to other validators regardless of where
+// WithRemoteIntrospection is placed among NewValidator's options.
+func TestWithRemoteIntrospectionOrdering(t *testing.T) {
+	futureExp := time.Now().Add(time.Hour).Unix()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"active":true,"exp":` + strconv.FormatInt(futureExp, 10) + `}`))
+	}))
+	defer srv.Close()
+
+	claims := &bwt.ClaimsMap{
+		bwt.ClaimsKeyExpirationTime: time.Now().Add(-time.Hour), // expired per the local claim
+	}
+
+	// WithVerifyExpiration is listed before WithRemoteIntrospection, the
+	// order a caller would naturally write it in.
+	v := bwt.NewValidator(
+		bwt.WithVerifyExpiration(true),
+		bwt.WithRemoteIntrospection(srv.URL, nil, "", ""),
+	)
+
+	if err := v.Validate(claims, "raw-token"); err != nil {
+		t.Fatalf("expected introspection's exp override to win, got: %v", err)
+	}
+}
+
+// TestWithRemoteIntrospectionEncodesToken checks that the raw token is
+// form-encoded rather than concatenated into the request body, so a raw
+// value containing '&' or '=' can't inject extra form fields into the
+// introspection request. raw is caller-supplied via Validator.Validate's
+// public API, so it can't be trusted to already be form-safe.
+func TestWithRemoteIntrospectionEncodesToken(t *testing.T) {
+	var gotForm map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error(err)
+		}
+		gotForm = map[string][]string(r.PostForm)
+		_, _ = w.Write([]byte(`{"active":true}`))
+	}))
+	defer srv.Close()
+
+	v := bwt.NewValidator(bwt.WithRemoteIntrospection(srv.URL, nil, "", ""))
+
+	raw := "abc&active=false&extra=1"
+	if err := v.Validate(&bwt.ClaimsMap{}, raw); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(gotForm) != 1 {
+		t.Fatalf("expected a single form field, got %v", gotForm)
+	}
+	if got := gotForm["token"]; len(got) != 1 || got[0] != raw {
+		t.Fatalf("expected token=%q, got %v", raw, gotForm)
+	}
+}
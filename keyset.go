@@ -0,0 +1,269 @@
+package bwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// KeySet represents a set of keys indexed by KeyID, allowing callers to
+// rotate signing keys without invalidating tokens signed with keys that
+// are still present in the set.
+type KeySet interface {
+	// Get returns the key identified by kid for the given algorithm.
+	Get(kid string, alg Algorithm) (Key, error)
+}
+
+// ErrKeyNotFound is returned by a KeySet when no key matches the requested KeyID.
+var ErrKeyNotFound = errors.New("key not found")
+
+// KeyfuncFromSet returns a Keyfunc that looks the key up in ks using the
+// token's KeyID and Algorithm.
+func KeyfuncFromSet(ks KeySet) Keyfunc {
+	return func(t *Token) (Key, error) {
+		return ks.Get(t.Claims.GetKeyID(), t.Algorithm)
+	}
+}
+
+// StaticKeySet is a KeySet backed by an in-memory map of kid to Key.
+type StaticKeySet map[string]Key
+
+// Get implements KeySet.
+func (s StaticKeySet) Get(kid string, _ Algorithm) (Key, error) {
+	key, ok := s[kid]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return key, nil
+}
+
+// jwk is a single entry of a remote key set document.
+type jwk struct {
+	KeyID     string `msgpack:"kid"`
+	Algorithm string `msgpack:"alg"`
+	Key       []byte `msgpack:"key"`
+}
+
+// jwksDoc is the wire format served by a RemoteKeySet endpoint.
+type jwksDoc struct {
+	Keys []jwk `msgpack:"keys"`
+}
+
+// RemoteKeySet is a KeySet that fetches its keys over HTTP and periodically
+// refreshes them, mirroring how OIDC/JWKS clients handle key rotation.
+type RemoteKeySet struct {
+	URL        string
+	HTTPClient *http.Client
+	Timeout    time.Duration
+
+	// MinRefresh is the minimum interval between refreshes regardless of
+	// what the endpoint's Cache-Control header says.
+	MinRefresh time.Duration
+
+	mu          sync.RWMutex
+	keys        map[string]jwk
+	nextAt      time.Time
+	lastAttempt time.Time
+	closeCh     chan struct{}
+	closeOn     sync.Once
+}
+
+// NewRemoteKeySet creates a RemoteKeySet for the given URL and starts its
+// background refresh goroutine. Call Close to stop it.
+func NewRemoteKeySet(url string) *RemoteKeySet {
+	ks := &RemoteKeySet{
+		URL:        url,
+		HTTPClient: http.DefaultClient,
+		Timeout:    10 * time.Second,
+		MinRefresh: time.Minute,
+		closeCh:    make(chan struct{}),
+	}
+	go ks.refreshLoop()
+	return ks
+}
+
+// Close stops the background refresh goroutine.
+func (ks *RemoteKeySet) Close() {
+	ks.closeOn.Do(func() { close(ks.closeCh) })
+}
+
+// Get implements KeySet, fetching the key set on first use if necessary.
+//
+// kid is taken from the token's claims before the tag is verified, so a
+// cache miss is attacker-controlled: anyone able to send a token with a
+// bogus kid can trigger one. throttledRefresh bounds the resulting
+// outbound requests to the backing endpoint to once per MinRefresh.
+func (ks *RemoteKeySet) Get(kid string, alg Algorithm) (Key, error) {
+	ks.mu.RLock()
+	k, ok := ks.keys[kid]
+	ks.mu.RUnlock()
+
+	if !ok {
+		if err := ks.throttledRefresh(); err != nil {
+			return nil, err
+		}
+		ks.mu.RLock()
+		k, ok = ks.keys[kid]
+		ks.mu.RUnlock()
+	}
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	if !strings.EqualFold(k.Algorithm, alg.Name()) {
+		return nil, ErrInvalidKeyType
+	}
+	return decodeJWKKey(alg, k.Key)
+}
+
+// throttledRefresh performs a synchronous refresh on a cache miss, but only
+// if at least MinRefresh has passed since the last miss-triggered attempt
+// (successful or not); refreshes made by the background loop do not count
+// toward that window. Within that window it returns ErrKeyNotFound without touching the
+// network, so a flood of lookups for bogus or unknown kids can't be turned
+// into a flood of requests against the backing endpoint.
+func (ks *RemoteKeySet) throttledRefresh() error {
+	min := ks.MinRefresh
+	if min <= 0 {
+		min = time.Minute
+	}
+
+	ks.mu.Lock()
+	if time.Since(ks.lastAttempt) < min {
+		ks.mu.Unlock()
+		return ErrKeyNotFound
+	}
+	ks.lastAttempt = time.Now()
+	ks.mu.Unlock()
+
+	return ks.refresh()
+}
+
+// decodeJWKKey converts the wire-format bytes of a jwk entry into the
+// concrete public key type alg.Verify expects, mirroring the KeyAs[T]
+// assertions in eddsa.go, rsa.go and ecdsa.go. HMAC-family algorithms are
+// rejected outright: publishing a symmetric secret over a KeySet endpoint
+// would hand it to anyone who can read the document.
+func decodeJWKKey(alg Algorithm, raw []byte) (Key, error) {
+	switch alg.(type) {
+	case *algoEd25519:
+		if len(raw) != ed25519.PublicKeySize {
+			return nil, ErrInvalidKey
+		}
+		return ed25519.PublicKey(raw), nil
+	case *algoRSA, *algoRSAPSS:
+		pub, err := x509.ParsePKCS1PublicKey(raw)
+		if err != nil {
+			return nil, &InvalidKeyTypeError{Expected: "*rsa.PublicKey", Received: "undecodable PKCS1 bytes"}
+		}
+		return pub, nil
+	case *algoECDSA:
+		pub, err := x509.ParsePKIXPublicKey(raw)
+		if err != nil {
+			return nil, &InvalidKeyTypeError{Expected: "*ecdsa.PublicKey", Received: "undecodable PKIX bytes"}
+		}
+		ecdsaKey, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, &InvalidKeyTypeError{Expected: "*ecdsa.PublicKey", Received: fmt.Sprintf("%T", pub)}
+		}
+		return ecdsaKey, nil
+	default:
+		return nil, ErrInvalidKeyType
+	}
+}
+
+func (ks *RemoteKeySet) refreshLoop() {
+	for {
+		wait := ks.MinRefresh
+		if err := ks.refresh(); err == nil {
+			ks.mu.RLock()
+			if d := time.Until(ks.nextAt); d > wait {
+				wait = d
+			}
+			ks.mu.RUnlock()
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ks.closeCh:
+			return
+		}
+	}
+}
+
+func (ks *RemoteKeySet) refresh() error {
+	timeout := ks.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ks.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	client := ks.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("bwt: remote key set responded with status " + resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var doc jwksDoc
+	if err := msgpack.Unmarshal(body, &doc); err != nil {
+		return err
+	}
+
+	keys := make(map[string]jwk, len(doc.Keys))
+	for _, k := range doc.Keys {
+		keys[k.KeyID] = k
+	}
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.nextAt = time.Now().Add(maxAge(resp.Header.Get("Cache-Control"), ks.MinRefresh))
+	ks.mu.Unlock()
+
+	return nil
+}
+
+// maxAge parses the max-age directive of a Cache-Control header, falling
+// back to def if it is absent or malformed.
+func maxAge(cacheControl string, def time.Duration) time.Duration {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(directive), "=")
+		if !ok || !strings.EqualFold(name, "max-age") {
+			continue
+		}
+		seconds, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	return def
+}
@@ -0,0 +1,144 @@
+package bwt_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/karalef/bwt"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// wireJWK and wireJWKSDoc mirror the unexported wire format RemoteKeySet
+// expects (the msgpack struct tags, not the type, are what matters).
+type wireJWK struct {
+	KeyID     string `msgpack:"kid"`
+	Algorithm string `msgpack:"alg"`
+	Key       []byte `msgpack:"key"`
+}
+
+type wireJWKSDoc struct {
+	Keys []wireJWK `msgpack:"keys"`
+}
+
+func TestRemoteKeySet(t *testing.T) {
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc := wireJWKSDoc{Keys: []wireJWK{
+		{KeyID: "ed", Algorithm: bwt.EdDSA.Name(), Key: edPub},
+		{KeyID: "rsa", Algorithm: bwt.RS256.Name(), Key: x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)},
+		{KeyID: "ec", Algorithm: bwt.ES256.Name(), Key: ecDER},
+		{KeyID: "hmac", Algorithm: bwt.HS256.Name(), Key: []byte("not a valid public key")},
+	}}
+	body, err := msgpack.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(body)
+	}))
+	defer srv.Close()
+
+	ks := bwt.NewRemoteKeySet(srv.URL)
+	defer ks.Close()
+
+	t.Run("EdDSA", func(t *testing.T) {
+		token := bwt.New(bwt.EdDSA, bwt.ClaimsMap{bwt.ClaimsKeyKeyID: "ed"})
+		signed, err := token.Authenticate(edPriv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := bwt.Parse(signed, bwt.KeyfuncFromSet(ks)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("RS256", func(t *testing.T) {
+		token := bwt.New(bwt.RS256, bwt.ClaimsMap{bwt.ClaimsKeyKeyID: "rsa"})
+		signed, err := token.Authenticate(rsaKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := bwt.Parse(signed, bwt.KeyfuncFromSet(ks)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("ES256", func(t *testing.T) {
+		token := bwt.New(bwt.ES256, bwt.ClaimsMap{bwt.ClaimsKeyKeyID: "ec"})
+		signed, err := token.Authenticate(ecKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := bwt.Parse(signed, bwt.KeyfuncFromSet(ks)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("HMACRejected", func(t *testing.T) {
+		if _, err := ks.Get("hmac", bwt.HS256); err == nil {
+			t.Fatal("expected error publishing an HMAC secret over a remote key set")
+		}
+	})
+}
+
+// TestRemoteKeySetThrottlesMisses checks that a flood of lookups for a kid
+// that never resolves doesn't turn into a flood of requests against the
+// backing endpoint. kid comes straight from an unverified token's claims,
+// so this path is reachable by anyone who can send a token to a server
+// using a RemoteKeySet, before the tag is ever checked.
+func TestRemoteKeySetThrottlesMisses(t *testing.T) {
+	body, err := msgpack.Marshal(wireJWKSDoc{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		_, _ = w.Write(body)
+	}))
+	defer srv.Close()
+
+	ks := bwt.NewRemoteKeySet(srv.URL)
+	defer ks.Close()
+
+	// give the background refresh loop's initial fetch a chance to land so
+	// it doesn't race with the synchronous misses below.
+	time.Sleep(50 * time.Millisecond)
+
+	for i := 0; i < 50; i++ {
+		if _, err := ks.Get("bogus-kid", bwt.HS256); !errors.Is(err, bwt.ErrKeyNotFound) {
+			t.Fatalf("call %d: expected ErrKeyNotFound, got %v", i, err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&hits); got > 2 {
+		t.Fatalf("expected at most 2 requests to the backing endpoint (startup refresh + one throttled miss), got %d", got)
+	}
+}
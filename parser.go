@@ -1,13 +1,22 @@
 package bwt
 
 import (
+	"bytes"
 	"errors"
+	"io"
 	"strings"
+	"sync"
 
 	"github.com/vmihailenco/msgpack/v5"
 )
 
 // Parser is used to parse, validate, and verify BWTs.
+//
+// Parse, ParseWithClaims and their Reader variants only handle BWT_ tokens;
+// a BWE_ token given to them fails with ErrTokenMalformed. Callers that
+// don't know ahead of time whether a token is a plaintext BWT or an
+// encrypted BWE should use ParseAny instead, which detects the prefix and
+// dispatches to the right pipeline.
 type Parser struct {
 	validator *Validator
 }
@@ -69,7 +78,7 @@ func (p *Parser) ParseWithClaims(tokenString string, claims Claims, keyFunc Keyf
 
 	// validate
 	if p.validator != nil {
-		if err := p.validator.Validate(token.Claims); err != nil {
+		if err := p.validator.Validate(token.Claims, tokenString); err != nil {
 			return token, errors.Join(ErrTokenInvalidClaims, err)
 		}
 	}
@@ -77,6 +86,31 @@ func (p *Parser) ParseWithClaims(tokenString string, claims Claims, keyFunc Keyf
 	return token, nil
 }
 
+// readBufPool holds the buffers used by ParseReader and ParseReaderWithClaims
+// to drain a token out of an io.Reader without allocating one per call.
+var readBufPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// ParseReader reads a token from r and parses, validates and verifies it like Parse.
+func (p *Parser) ParseReader(r io.Reader, keyFunc Keyfunc) (*Token, error) {
+	return p.ParseReaderWithClaims(r, new(ClaimsMap), keyFunc)
+}
+
+// ParseReaderWithClaims reads a token from r and parses, validates and
+// verifies it like ParseWithClaims. Claims must be pointer.
+func (p *Parser) ParseReaderWithClaims(r io.Reader, claims Claims, keyFunc Keyfunc) (*Token, error) {
+	buf := readBufPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer readBufPool.Put(buf)
+
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+
+	return p.ParseWithClaims(buf.String(), claims, keyFunc)
+}
+
 // Raw is the raw token data after unverified parsing.
 type Raw struct {
 	Parts  []string
@@ -125,6 +159,16 @@ func ParseWithClaims(tokenString string, claims Claims, keyFunc Keyfunc, v ...*V
 	return NewParser(v...).ParseWithClaims(tokenString, claims, keyFunc)
 }
 
+// ParseReader is a shortcut for NewParser().ParseReader().
+func ParseReader(r io.Reader, keyFunc Keyfunc, v ...*Validator) (*Token, error) {
+	return NewParser(v...).ParseReader(r, keyFunc)
+}
+
+// ParseReaderWithClaims is a shortcut for NewParser().ParseReaderWithClaims().
+func ParseReaderWithClaims(r io.Reader, claims Claims, keyFunc Keyfunc, v ...*Validator) (*Token, error) {
+	return NewParser(v...).ParseReaderWithClaims(r, claims, keyFunc)
+}
+
 var (
 	ErrTokenMalformed     = errors.New("token is malformed")
 	ErrTokenUnverifiable  = errors.New("token is unverifiable")
@@ -0,0 +1,31 @@
+package bwt_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/karalef/bwt"
+)
+
+func TestRSA(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	algs := []bwt.Algorithm{bwt.RS256, bwt.RS384, bwt.RS512, bwt.PS256, bwt.PS384, bwt.PS512}
+	for _, alg := range algs {
+		t.Run(alg.Name(), func(t *testing.T) {
+			token := bwt.New(alg, bwt.ClaimsMap{bwt.ClaimsKeySubject: "1234567890"})
+			signed, err := token.Authenticate(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := bwt.Parse(signed, bwt.KeyfuncFrom(&key.PublicKey)); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
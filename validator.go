@@ -18,10 +18,18 @@ type Validator struct {
 	leeway   time.Duration
 	timeFunc func() time.Time
 	now      time.Time
+	raw      string
 
-	validators []ValidatorFunc
+	preValidators []ValidatorFunc
+	validators    []ValidatorFunc
 }
 
+// Raw returns the raw, encoded token string supplied to Validate, if any.
+// It is set for every validator func of a Validate call that was given one
+// (Parser always passes it), and is read by validators that need the token
+// as it was received, such as WithRemoteIntrospection.
+func (v *Validator) Raw() string { return v.raw }
+
 // Now returns the current time.
 func (v *Validator) Now() time.Time {
 	if !v.now.IsZero() {
@@ -78,12 +86,39 @@ func WithValidator(f ValidatorFunc) ValidatorOption {
 	}
 }
 
+// withPreValidator returns the ValidatorOption to add a validator func that
+// runs before every func added via WithValidator, regardless of the order
+// options were supplied in. It is unexported because the only validator
+// that needs to run first is WithRemoteIntrospection, which may rewrite
+// claims (e.g. exp) that later validators check.
+func withPreValidator(f ValidatorFunc) ValidatorOption {
+	return func(v *Validator) {
+		v.preValidators = append(v.preValidators, f)
+	}
+}
+
 // Validate validates the given claims. It will also perform any custom
 // validation if claims implements the [ClaimsValidator] interface.
-func (v *Validator) Validate(claims Claims) (err error) {
-	errs := make([]error, 0, len(v.validators))
-	for _, validate := range v.validators {
-		if err := validate(v, claims); err != nil {
+// raw, if supplied, is the original encoded token string and is made
+// available to validators via Raw, such as WithRemoteIntrospection.
+//
+// Validate is safe to call concurrently on the same *Validator: the raw
+// token and cached "now" are per-call state, not shared mutable fields, so
+// one caller's token can never leak into another's validator funcs.
+func (v *Validator) Validate(claims Claims, raw ...string) (err error) {
+	call := *v
+	if len(raw) > 0 {
+		call.raw = raw[0]
+	}
+
+	errs := make([]error, 0, len(call.preValidators)+len(call.validators))
+	for _, validate := range call.preValidators {
+		if err := validate(&call, claims); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, validate := range call.validators {
+		if err := validate(&call, claims); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -94,7 +129,10 @@ func (v *Validator) Validate(claims Claims) (err error) {
 		}
 	}
 
-	return errors.Join(errs...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{errs: errs}
 }
 
 // WithVerifyExpiration returns the ValidatorOption for specifying the
@@ -105,7 +143,11 @@ func WithVerifyExpiration(required bool) ValidatorOption {
 		if exp.IsZero() {
 			return errorIfRequired(required, ClaimsKeyExpirationTime)
 		}
-		return errorIfFalse(v.PTime().Before(exp), ErrTokenExpired)
+		now := v.PTime()
+		if now.Before(exp) {
+			return nil
+		}
+		return &TokenExpiredError{ExpiredBy: now.Sub(exp)}
 	})
 }
 
@@ -196,7 +238,7 @@ func errorIfFalse(value bool, err error) error {
 
 func errorIfRequired(required bool, claim string) error {
 	if required {
-		return errors.Join(errors.New(claim+" claim is required"), ErrTokenRequiredClaimMissing)
+		return &RequiredClaimMissingError{Claim: claim}
 	}
 	return nil
 }
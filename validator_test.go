@@ -0,0 +1,43 @@
+package bwt_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/karalef/bwt"
+)
+
+// TestValidatorConcurrentUse exercises a *Validator shared across
+// goroutines, as bwthttp.Middleware does. Each request's raw token must
+// stay local to its own Validate call and not leak into another's.
+func TestValidatorConcurrentUse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		tok := r.FormValue("token")
+		_, _ = w.Write([]byte(`{"active":true,"sub":"` + tok + `"}`))
+	}))
+	defer srv.Close()
+
+	v := bwt.NewValidator(bwt.WithRemoteIntrospection(srv.URL, nil, "", ""))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			raw := "token-" + time.Duration(i).String()
+			claims := &bwt.ClaimsMap{}
+			if err := v.Validate(claims, raw); err != nil {
+				t.Error(err)
+				return
+			}
+			if claims.GetSubject() != raw {
+				t.Errorf("got %q want %q", claims.GetSubject(), raw)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,101 @@
+package bwt
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/crypto/sha3"
+)
+
+// X25519HKDF is the X25519+HKDF+XChaCha20-Poly1305 public-key
+// EncryptionAlgorithm. Encrypt expects an *ecdh.PublicKey (the recipient's
+// static public key); Decrypt expects the matching *ecdh.PrivateKey.
+//
+// An ephemeral X25519 key pair is generated per call to Encrypt. Its public
+// key is prepended to the nonce on the wire so that Decrypt can recompute
+// the shared secret without any out-of-band information.
+var X25519HKDF *algoX25519HKDF
+
+func init() {
+	X25519HKDF = new(algoX25519HKDF)
+	RegisterEncryptionAlgorithm(X25519HKDF)
+}
+
+type algoX25519HKDF struct{}
+
+func (*algoX25519HKDF) Name() string { return "X25519HKDF" }
+
+// Encrypt implements token encryption. Key must be an *ecdh.PublicKey.
+func (a *algoX25519HKDF) Encrypt(prefix string, plaintext []byte, key Key) (nonce, ciphertext, tag []byte, err error) {
+	recipient, err := KeyAs[*ecdh.PublicKey](key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	curve := ecdh.X25519()
+	ephemeral, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	shared, err := ephemeral.ECDH(recipient)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	wrapKey, err := deriveWrapKey(shared, prefix)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	innerNonce, ciphertext, tag, err := XChaCha20Poly1305.Encrypt(prefix, plaintext, wrapKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	nonce = append(ephemeral.PublicKey().Bytes(), innerNonce...)
+	return nonce, ciphertext, tag, nil
+}
+
+// Decrypt implements token decryption. Key must be an *ecdh.PrivateKey.
+func (a *algoX25519HKDF) Decrypt(prefix string, nonce, ciphertext, tag []byte, key PrivateKey) ([]byte, error) {
+	recipient, err := KeyAs[*ecdh.PrivateKey](key)
+	if err != nil {
+		return nil, err
+	}
+
+	curve := ecdh.X25519()
+	const pubKeySize = 32
+	if len(nonce) < pubKeySize {
+		return nil, ErrTagInvalid
+	}
+	ephemeralPub, err := curve.NewPublicKey(nonce[:pubKeySize])
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	shared, err := recipient.ECDH(ephemeralPub)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapKey, err := deriveWrapKey(shared, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return XChaCha20Poly1305.Decrypt(prefix, nonce[pubKeySize:], ciphertext, tag, wrapKey)
+}
+
+// deriveWrapKey derives a 32-byte symmetric key from an X25519 shared secret
+// using HKDF-SHA3-256 with prefix as the context-binding info parameter.
+func deriveWrapKey(shared []byte, prefix string) ([]byte, error) {
+	reader := hkdf.New(sha3.New256, shared, nil, []byte(prefix))
+	wrapKey := make([]byte, 32)
+	if _, err := io.ReadFull(reader, wrapKey); err != nil {
+		return nil, err
+	}
+	return wrapKey, nil
+}
@@ -0,0 +1,75 @@
+package bwt
+
+import (
+	"crypto/rand"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// XChaCha20Poly1305 is the XChaCha20-Poly1305 EncryptionAlgorithm.
+// Expects a 32-byte symmetric key for both Encrypt and Decrypt.
+var XChaCha20Poly1305 *algoXChaCha20Poly1305
+
+func init() {
+	XChaCha20Poly1305 = new(algoXChaCha20Poly1305)
+	RegisterEncryptionAlgorithm(XChaCha20Poly1305)
+}
+
+type algoXChaCha20Poly1305 struct{}
+
+func (*algoXChaCha20Poly1305) Name() string { return "XCHACHA20POLY1305" }
+
+// Encrypt implements token encryption. Key must be a 32-byte []byte.
+func (a *algoXChaCha20Poly1305) Encrypt(prefix string, plaintext []byte, key Key) (nonce, ciphertext, tag []byte, err error) {
+	keyBytes, err := KeyAs[[]byte](key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	aead, err := chacha20poly1305.NewX(keyBytes)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	nonce = make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, nil, err
+	}
+
+	sealed := aead.Seal(nil, nonce, plaintext, []byte(prefix))
+	ciphertext, tag = splitTag(sealed, aead.Overhead())
+	return nonce, ciphertext, tag, nil
+}
+
+// Decrypt implements token decryption. Key must be a 32-byte []byte.
+func (a *algoXChaCha20Poly1305) Decrypt(prefix string, nonce, ciphertext, tag []byte, key PrivateKey) ([]byte, error) {
+	keyBytes, err := KeyAs[[]byte](key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := chacha20poly1305.NewX(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, ErrInvalidKey
+	}
+	if len(tag) != aead.Overhead() {
+		return nil, ErrTagInvalid
+	}
+
+	plaintext, err := aead.Open(nil, nonce, append(ciphertext, tag...), []byte(prefix))
+	if err != nil {
+		return nil, ErrWrongTag
+	}
+	return plaintext, nil
+}
+
+// splitTag splits an AEAD-sealed buffer into ciphertext and its trailing
+// authentication tag of the given size.
+func splitTag(sealed []byte, tagSize int) (ciphertext, tag []byte) {
+	n := len(sealed) - tagSize
+	return sealed[:n], sealed[n:]
+}